@@ -0,0 +1,133 @@
+package crumb
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// Option configures an App via functional options, e.g.
+// crumb.New(crumb.WithReadTimeout(5*time.Second)).
+type Option func(*App)
+
+// App is the framework's entry point: a Router ready for route
+// registration, paired with the *http.Server that serves it and wired up
+// for graceful shutdown, HTTP/2 and TLS.
+type App struct {
+	*Router
+	server        *http.Server
+	h2c           bool
+	h2Config      *http2.Server
+	shutdownHooks []func(context.Context)
+}
+
+// WithReadTimeout sets http.Server.ReadTimeout.
+func WithReadTimeout(d time.Duration) Option {
+	return func(a *App) { a.server.ReadTimeout = d }
+}
+
+// WithWriteTimeout sets http.Server.WriteTimeout.
+func WithWriteTimeout(d time.Duration) Option {
+	return func(a *App) { a.server.WriteTimeout = d }
+}
+
+// WithIdleTimeout sets http.Server.IdleTimeout.
+func WithIdleTimeout(d time.Duration) Option {
+	return func(a *App) { a.server.IdleTimeout = d }
+}
+
+// WithH2C enables HTTP/2 over cleartext connections (h2c), for running
+// behind a proxy that terminates TLS.
+func WithH2C(enabled bool) Option {
+	return func(a *App) { a.h2c = enabled }
+}
+
+// WithMaxConcurrentStreams sets the maximum number of concurrent HTTP/2
+// streams the server accepts per connection.
+func WithMaxConcurrentStreams(n uint32) Option {
+	return func(a *App) { a.h2Config.MaxConcurrentStreams = n }
+}
+
+// New creates an App with an empty Router, applying any options. Routes
+// are registered directly on the returned App (App.GET, App.Use, ...).
+func New(opts ...Option) *App {
+	a := &App{
+		Router:   newRouter(),
+		server:   &http.Server{},
+		h2Config: &http2.Server{},
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	var handler http.Handler = a.Router
+	if a.h2c {
+		handler = h2c.NewHandler(handler, a.h2Config)
+	}
+	a.server.Handler = handler
+	return a
+}
+
+// OnShutdown registers a hook that runs during Shutdown, after in-flight
+// requests have drained.
+func (a *App) OnShutdown(fn func(context.Context)) {
+	a.shutdownHooks = append(a.shutdownHooks, fn)
+}
+
+// Listen starts serving HTTP on addr and blocks until the server stops.
+// It traps SIGINT/SIGTERM, draining in-flight requests via Shutdown
+// before returning.
+func (a *App) Listen(addr string) error {
+	a.server.Addr = addr
+	return a.run(a.server.ListenAndServe)
+}
+
+// ListenTLS starts serving HTTPS on addr using the given certificate and
+// key files, negotiating HTTP/2 via ALPN, and blocks until the server
+// stops. It traps SIGINT/SIGTERM the same way Listen does.
+func (a *App) ListenTLS(addr, cert, key string) error {
+	a.server.Addr = addr
+	if err := http2.ConfigureServer(a.server, a.h2Config); err != nil {
+		return err
+	}
+	return a.run(func() error { return a.server.ListenAndServeTLS(cert, key) })
+}
+
+// run serves in the background until serve returns or SIGINT/SIGTERM is
+// received, in which case it gracefully shuts the server down.
+func (a *App) run(serve func() error) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		if err := serve(); err != nil && err != http.ErrServerClosed {
+			errCh <- err
+			return
+		}
+		errCh <- nil
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return a.Shutdown(context.Background())
+	}
+}
+
+// Shutdown gracefully stops the server, waiting for in-flight requests to
+// drain before running any hooks registered via OnShutdown.
+func (a *App) Shutdown(ctx context.Context) error {
+	err := a.server.Shutdown(ctx)
+	for _, hook := range a.shutdownHooks {
+		hook(ctx)
+	}
+	return err
+}
@@ -0,0 +1,58 @@
+package crumb
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNewAppliesOptions(t *testing.T) {
+	app := New(
+		WithReadTimeout(5*time.Second),
+		WithWriteTimeout(6*time.Second),
+		WithIdleTimeout(7*time.Second),
+		WithH2C(true),
+	)
+
+	if app.server.ReadTimeout != 5*time.Second {
+		t.Fatalf("ReadTimeout = %v, want 5s", app.server.ReadTimeout)
+	}
+	if app.server.WriteTimeout != 6*time.Second {
+		t.Fatalf("WriteTimeout = %v, want 6s", app.server.WriteTimeout)
+	}
+	if app.server.IdleTimeout != 7*time.Second {
+		t.Fatalf("IdleTimeout = %v, want 7s", app.server.IdleTimeout)
+	}
+	if !app.h2c {
+		t.Fatalf("h2c = false, want true")
+	}
+}
+
+func TestShutdownRunsHooksAfterDraining(t *testing.T) {
+	app := New()
+
+	var ran bool
+	app.OnShutdown(func(ctx context.Context) { ran = true })
+
+	if err := app.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown returned error: %v", err)
+	}
+	if !ran {
+		t.Fatalf("shutdown hook did not run")
+	}
+}
+
+func TestShutdownRunsHooksInRegistrationOrder(t *testing.T) {
+	app := New()
+
+	var order []string
+	app.OnShutdown(func(ctx context.Context) { order = append(order, "first") })
+	app.OnShutdown(func(ctx context.Context) { order = append(order, "second") })
+
+	if err := app.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown returned error: %v", err)
+	}
+	if len(order) != 2 || order[0] != "first" || order[1] != "second" {
+		t.Fatalf("order = %v, want [first second]", order)
+	}
+}
@@ -0,0 +1,142 @@
+package crumb
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Bind decodes the request into v, a pointer to a struct. The body is
+// decoded first (see Decoder/RegisterDecoder), based on Content-Type;
+// fields tagged `crumb:"path=..."`, `crumb:"query=..."`, `crumb:"header=..."`
+// or `crumb:"form=..."` are then filled from the matching part of the
+// request, overriding whatever the body decode set, so one struct can
+// gather values from multiple parts of the request.
+func (c *HttpContext) Bind(v any) error {
+	if err := decodeBody(c.req, v); err != nil {
+		return err
+	}
+	return bindTaggedFields(c, v)
+}
+
+// BindAndValidate calls Bind and then validates the result with the
+// package's Validator (see SetValidator), returning a ValidationError
+// when validation fails.
+func (c *HttpContext) BindAndValidate(v any) error {
+	if err := c.Bind(v); err != nil {
+		return err
+	}
+	return defaultValidator.Validate(v)
+}
+
+func bindTaggedFields(c *HttpContext, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("crumb: Bind target must be a pointer to a struct")
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		tag := field.Tag.Get("crumb")
+		if tag == "" {
+			continue
+		}
+		source, key, ok := strings.Cut(tag, "=")
+		if !ok {
+			continue
+		}
+
+		var value string
+		switch source {
+		case "path":
+			value = c.Param(key)
+		case "query":
+			value = c.Query(key)
+		case "header":
+			value = c.req.Header.Get(key)
+		case "form":
+			value = c.req.FormValue(key)
+		default:
+			continue
+		}
+		if value == "" {
+			continue
+		}
+		if err := setField(rv.Field(i), value); err != nil {
+			return fmt.Errorf("crumb: binding field %q: %w", field.Name, err)
+		}
+	}
+	return nil
+}
+
+// populateFromValues fills the exported fields of v (a pointer to a
+// struct) from values, for a form/multipart body. A field tagged
+// `crumb:"form=..."` is looked up under its tag's key; any other field is
+// looked up under its Go field name, matched exactly (case-sensitively).
+func populateFromValues(v any, values url.Values) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("crumb: Bind target must be a pointer to a struct")
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		key := field.Name
+		if tag := field.Tag.Get("crumb"); tag != "" {
+			if source, tagKey, ok := strings.Cut(tag, "="); ok && source == "form" {
+				key = tagKey
+			}
+		}
+		value := values.Get(key)
+		if value == "" {
+			continue
+		}
+		if err := setField(rv.Field(i), value); err != nil {
+			return fmt.Errorf("crumb: binding field %q: %w", field.Name, err)
+		}
+	}
+	return nil
+}
+
+func setField(field reflect.Value, value string) error {
+	if !field.CanSet() {
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field kind %s", field.Kind())
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package crumb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type createUser struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+// TestBindAndValidateStructuredErrorBody guards against the response
+// encoder flattening a ValidationError's field→message map into a single
+// opaque string: the JSON body must expose each field directly.
+func TestBindAndValidateStructuredErrorBody(t *testing.T) {
+	router := newRouter()
+	router.POST("/users", func(ctx *HttpContext) any {
+		var body createUser
+		return ctx.BindAndValidate(&body)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"Ann"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusUnprocessableEntity)
+	}
+	if !strings.Contains(rec.Body.String(), `"Email"`) {
+		t.Fatalf("body = %s, want a structured per-field object containing \"Email\"", rec.Body.String())
+	}
+	if strings.Contains(rec.Body.String(), `"error"`) {
+		t.Fatalf("body = %s, should not be flattened into an ErrorResponse envelope", rec.Body.String())
+	}
+}
@@ -0,0 +1,90 @@
+package crumb
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// HttpContext carries the request/response pair for a single in-flight
+// request and accumulates the helpers handlers and middleware use to
+// inspect the request and produce a response.
+type HttpContext struct {
+	res     http.ResponseWriter
+	req     *http.Request
+	params  []param
+	status  int
+	written bool
+	values  map[string]any
+}
+
+// SetHeader sets a response header. It must be called before the response
+// is written.
+func (c *HttpContext) SetHeader(key, value string) {
+	c.res.Header().Set(key, value)
+}
+
+// Request returns the underlying *http.Request.
+func (c *HttpContext) Request() *http.Request {
+	return c.req
+}
+
+// ResponseWriter returns the underlying http.ResponseWriter, for
+// middleware that needs to wrap it (gzip compression, response metrics,
+// ...).
+func (c *HttpContext) ResponseWriter() http.ResponseWriter {
+	return c.res
+}
+
+// SetResponseWriter replaces the underlying http.ResponseWriter. It is
+// meant for middleware that wraps the writer before calling the next
+// handler, and must preserve whatever optional interfaces (http.Flusher,
+// http.Hijacker) the original writer implements.
+func (c *HttpContext) SetResponseWriter(w http.ResponseWriter) {
+	c.res = w
+}
+
+// Set stores an arbitrary value on the context, keyed by name, for later
+// retrieval via Get. Middleware uses this to stash request-scoped data
+// (a request ID, an authenticated user, ...) for handlers downstream.
+func (c *HttpContext) Set(key string, value any) {
+	if c.values == nil {
+		c.values = make(map[string]any)
+	}
+	c.values[key] = value
+}
+
+// Get retrieves a value previously stored with Set, or nil if key was
+// never set.
+func (c *HttpContext) Get(key string) any {
+	return c.values[key]
+}
+
+// Param returns the value captured for a named path parameter (":id" or
+// "*path" in the registered route), or "" if no such parameter exists.
+func (c *HttpContext) Param(name string) string {
+	for _, p := range c.params {
+		if p.key == name {
+			return p.value
+		}
+	}
+	return ""
+}
+
+// ParamInt returns the named path parameter parsed as an int.
+func (c *HttpContext) ParamInt(name string) (int, error) {
+	return strconv.Atoi(c.Param(name))
+}
+
+// Query returns the value of a query string parameter, or "" if absent.
+func (c *HttpContext) Query(name string) string {
+	return c.req.URL.Query().Get(name)
+}
+
+// QueryDefault returns the value of a query string parameter, or def if
+// the parameter is absent or empty.
+func (c *HttpContext) QueryDefault(name, def string) string {
+	if v := c.Query(name); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,82 @@
+package crumb
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"net/http"
+)
+
+// Decoder decodes a request body into v. Decoders are selected by the
+// request's Content-Type; register additional ones (msgpack, protobuf,
+// ...) with RegisterDecoder.
+type Decoder interface {
+	Decode(req *http.Request, v any) error
+}
+
+// DecoderFunc adapts a function to a Decoder.
+type DecoderFunc func(req *http.Request, v any) error
+
+// Decode calls f(req, v).
+func (f DecoderFunc) Decode(req *http.Request, v any) error {
+	return f(req, v)
+}
+
+var decoders = map[string]Decoder{
+	"application/json":                  DecoderFunc(decodeJSON),
+	"application/xml":                   DecoderFunc(decodeXML),
+	"application/x-www-form-urlencoded": DecoderFunc(decodeForm),
+	"multipart/form-data":               DecoderFunc(decodeMultipart),
+}
+
+// RegisterDecoder installs d as the Decoder used for contentType,
+// replacing any existing one (including the built-in JSON, XML, form and
+// multipart decoders).
+func RegisterDecoder(contentType string, d Decoder) {
+	decoders[contentType] = d
+}
+
+// decodeBody picks a Decoder by the request's Content-Type and decodes
+// the body into v. It is a no-op when the request carries no body.
+func decodeBody(req *http.Request, v any) error {
+	if req.Body == nil || req.ContentLength == 0 {
+		return nil
+	}
+	ct := req.Header.Get("Content-Type")
+	if ct == "" {
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return fmt.Errorf("crumb: parsing Content-Type: %w", err)
+	}
+	dec, ok := decoders[mediaType]
+	if !ok {
+		return fmt.Errorf("crumb: no decoder registered for content type %q", mediaType)
+	}
+	return dec.Decode(req, v)
+}
+
+func decodeJSON(req *http.Request, v any) error {
+	return json.NewDecoder(req.Body).Decode(v)
+}
+
+func decodeXML(req *http.Request, v any) error {
+	return xml.NewDecoder(req.Body).Decode(v)
+}
+
+func decodeForm(req *http.Request, v any) error {
+	if err := req.ParseForm(); err != nil {
+		return err
+	}
+	return populateFromValues(v, req.PostForm)
+}
+
+func decodeMultipart(req *http.Request, v any) error {
+	const maxMemory = 32 << 20 // 32MB
+	if err := req.ParseMultipartForm(maxMemory); err != nil {
+		return err
+	}
+	return populateFromValues(v, req.PostForm)
+}
@@ -0,0 +1,59 @@
+package crumb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestDecodeBodyDispatchesByContentType(t *testing.T) {
+	type payload struct {
+		Name string `json:"name" xml:"Name"`
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{"json", "application/json", `{"name":"ana"}`},
+		{"xml", "application/xml", `<payload><Name>ana</Name></payload>`},
+		{"form", "application/x-www-form-urlencoded", url.Values{"Name": {"ana"}}.Encode()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+
+			var got payload
+			if err := decodeBody(req, &got); err != nil {
+				t.Fatalf("decodeBody: %v", err)
+			}
+			if got.Name != "ana" {
+				t.Fatalf("Name = %q, want %q", got.Name, "ana")
+			}
+		})
+	}
+}
+
+func TestDecodeBodyRejectsUnknownContentType(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("whatever"))
+	req.Header.Set("Content-Type", "application/vnd.unknown")
+
+	var v struct{}
+	if err := decodeBody(req, &v); err == nil {
+		t.Fatalf("decodeBody: want error for unregistered content type, got nil")
+	}
+}
+
+func TestDecodeBodyNoopWithoutBody(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	var v struct{}
+	if err := decodeBody(req, &v); err != nil {
+		t.Fatalf("decodeBody: %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"log"
+
+	"github.com/tuplescompany/crumbjs"
+	"github.com/tuplescompany/crumbjs/middleware"
+)
+
+type stdLogger struct{}
+
+func (stdLogger) Log(fields map[string]any) {
+	log.Println(fields)
+}
+
+func main() {
+	app := crumb.New()
+	app.Use(middleware.Recover(), middleware.RequestID(), middleware.NewLogger(stdLogger{}))
+
+	app.GET("/", func(ctx *crumb.HttpContext) any {
+		return "Hola, mundo!"
+	})
+
+	app.GET("/users/:id", func(ctx *crumb.HttpContext) any {
+		id := ctx.Param("id")
+		return crumb.Negotiate(
+			crumb.Representation{ContentType: "application/json", Value: map[string]string{"id": id}},
+			crumb.Representation{ContentType: "text/html", Value: crumb.HTML("<p>user " + id + "</p>")},
+		)
+	})
+
+	type createUser struct {
+		ID    int    `crumb:"path=id" validate:"required"`
+		Name  string `json:"name" validate:"required"`
+		Email string `json:"email" validate:"required,email"`
+	}
+
+	app.POST("/users/:id", func(ctx *crumb.HttpContext) any {
+		var body createUser
+		if err := ctx.BindAndValidate(&body); err != nil {
+			return err
+		}
+		return body
+	})
+
+	app.Static("/assets", "./public", crumb.WithCacheControl("public, max-age=3600"))
+
+	log.Fatal(app.Listen(":8080"))
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/tuplescompany/crumbjs"
+)
+
+// CORSConfig configures the CORS middleware.
+type CORSConfig struct {
+	// AllowOrigins lists origins allowed to make cross-origin requests.
+	// "*" allows any origin.
+	AllowOrigins []string
+	// AllowMethods lists methods advertised in the preflight response.
+	AllowMethods []string
+	// AllowHeaders lists request headers advertised in the preflight
+	// response.
+	AllowHeaders []string
+	// AllowCredentials sets Access-Control-Allow-Credentials.
+	AllowCredentials bool
+	// MaxAge, if > 0, sets Access-Control-Max-Age in seconds.
+	MaxAge int
+}
+
+// CORS returns middleware that sets Access-Control-* response headers
+// according to cfg and answers preflight OPTIONS requests directly.
+func CORS(cfg CORSConfig) crumb.Middleware {
+	allowMethods := strings.Join(cfg.AllowMethods, ", ")
+	allowHeaders := strings.Join(cfg.AllowHeaders, ", ")
+
+	return func(next crumb.HandlerFunc) crumb.HandlerFunc {
+		return func(ctx *crumb.HttpContext) any {
+			origin := ctx.Request().Header.Get("Origin")
+			if origin != "" && originAllowed(cfg.AllowOrigins, origin) {
+				ctx.SetHeader("Access-Control-Allow-Origin", origin)
+				ctx.SetHeader("Vary", "Origin")
+				if cfg.AllowCredentials {
+					ctx.SetHeader("Access-Control-Allow-Credentials", "true")
+				}
+			}
+
+			if ctx.Request().Method != http.MethodOptions {
+				return next(ctx)
+			}
+
+			if allowMethods != "" {
+				ctx.SetHeader("Access-Control-Allow-Methods", allowMethods)
+			}
+			if allowHeaders != "" {
+				ctx.SetHeader("Access-Control-Allow-Headers", allowHeaders)
+			}
+			if cfg.MaxAge > 0 {
+				ctx.SetHeader("Access-Control-Max-Age", strconv.Itoa(cfg.MaxAge))
+			}
+			return ctx.String(http.StatusNoContent, "")
+		}
+	}
+}
+
+func originAllowed(allowed []string, origin string) bool {
+	for _, a := range allowed {
+		if a == "*" || a == origin {
+			return true
+		}
+	}
+	return false
+}
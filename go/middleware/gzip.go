@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/tuplescompany/crumbjs"
+)
+
+// Gzip returns middleware that compresses the response body when the
+// client's Accept-Encoding header includes gzip.
+func Gzip() crumb.Middleware {
+	return func(next crumb.HandlerFunc) crumb.HandlerFunc {
+		return func(ctx *crumb.HttpContext) any {
+			if !strings.Contains(ctx.Request().Header.Get("Accept-Encoding"), "gzip") {
+				return next(ctx)
+			}
+
+			ctx.SetHeader("Content-Encoding", "gzip")
+			ctx.SetHeader("Vary", "Accept-Encoding")
+
+			gz := gzip.NewWriter(ctx.ResponseWriter())
+			defer gz.Close()
+
+			ctx.SetResponseWriter(&gzipWriter{ResponseWriter: ctx.ResponseWriter(), gz: gz})
+			return next(ctx)
+		}
+	}
+}
+
+// gzipWriter wraps an http.ResponseWriter to write the body through a
+// gzip.Writer, while preserving Flusher/Hijacker support.
+type gzipWriter struct {
+	http.ResponseWriter
+	gz *gzip.Writer
+}
+
+func (w *gzipWriter) Write(b []byte) (int, error) {
+	return w.gz.Write(b)
+}
+
+func (w *gzipWriter) Flush() {
+	_ = w.gz.Flush()
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *gzipWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("middleware: underlying ResponseWriter does not support Hijack")
+	}
+	return h.Hijack()
+}
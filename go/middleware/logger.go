@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/tuplescompany/crumbjs"
+)
+
+// Logger is the interface a structured logging backend must satisfy to
+// back the request logger middleware. It is small enough to be
+// implemented by a thin wrapper around zerolog, logrus, slog or similar.
+type Logger interface {
+	Log(fields map[string]any)
+}
+
+// NewLogger returns middleware that logs method, path, status, response
+// size, duration and request ID for every request via log.
+func NewLogger(log Logger) crumb.Middleware {
+	return func(next crumb.HandlerFunc) crumb.HandlerFunc {
+		return func(ctx *crumb.HttpContext) any {
+			sw := newStatusWriter(ctx.ResponseWriter())
+			ctx.SetResponseWriter(sw)
+
+			start := time.Now()
+			result := next(ctx)
+
+			log.Log(map[string]any{
+				"method":     ctx.Request().Method,
+				"path":       ctx.Request().URL.Path,
+				"status":     sw.status,
+				"bytes":      sw.bytes,
+				"duration":   time.Since(start),
+				"request_id": RequestIDFrom(ctx),
+			})
+			return result
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/tuplescompany/crumbjs"
+)
+
+type recordingLogger struct {
+	fields map[string]any
+}
+
+func (l *recordingLogger) Log(fields map[string]any) {
+	l.fields = fields
+}
+
+// TestLoggerObservesEncodedResponse guards against the logger reading
+// status/bytes before the router has actually written the response: a
+// handler that returns an error must be logged with the 500 status the
+// response encoder writes, not the 200 that statusWriter starts with.
+func TestLoggerObservesEncodedResponse(t *testing.T) {
+	rl := &recordingLogger{}
+
+	router := crumb.New()
+	router.Use(NewLogger(rl))
+	router.GET("/boom", func(ctx *crumb.HttpContext) any {
+		return errors.New("boom")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/boom", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("response status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	if got, want := rl.fields["status"], http.StatusInternalServerError; got != want {
+		t.Fatalf("logged status = %v, want %v", got, want)
+	}
+	if got := rl.fields["bytes"]; got == 0 {
+		t.Fatalf("logged bytes = %v, want > 0", got)
+	}
+}
+
+func TestCORSPreflight(t *testing.T) {
+	cors := CORS(CORSConfig{
+		AllowOrigins: []string{"https://example.com"},
+		AllowMethods: []string{http.MethodGet, http.MethodPost},
+	})
+	handler := cors(func(ctx *crumb.HttpContext) any { return "unreached" })
+
+	req := httptest.NewRequest(http.MethodOptions, "/", nil)
+	req.Header.Set("Origin", "https://example.com")
+	rec := httptest.NewRecorder()
+
+	router := crumb.New()
+	router.OPTIONS("/", handler)
+	router.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://example.com" {
+		t.Fatalf("Access-Control-Allow-Origin = %q, want %q", got, "https://example.com")
+	}
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+}
+
+// TestCORSPreflightWithoutExplicitOPTIONSRoute guards against preflight
+// requests 404ing when a path only registers e.g. POST: the router must
+// still run CORS middleware for the implicit OPTIONS request.
+func TestCORSPreflightWithoutExplicitOPTIONSRoute(t *testing.T) {
+	router := crumb.New()
+	router.Use(CORS(CORSConfig{
+		AllowOrigins: []string{"https://example.com"},
+		AllowMethods: []string{http.MethodPost},
+	}))
+	router.POST("/users", func(ctx *crumb.HttpContext) any { return "created" })
+
+	req := httptest.NewRequest(http.MethodOptions, "/users", nil)
+	req.Header.Set("Origin", "https://example.com")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://example.com" {
+		t.Fatalf("Access-Control-Allow-Origin = %q, want %q", got, "https://example.com")
+	}
+}
+
+// TestGzipCompressesWhenAcceptEncodingMatches guards the gzip.Writer
+// wiring in gzipWriter: the response must actually be gzip-encoded and
+// gunzip back to the original body.
+func TestGzipCompressesWhenAcceptEncodingMatches(t *testing.T) {
+	router := crumb.New()
+	router.Use(Gzip())
+	router.GET("/", func(ctx *crumb.HttpContext) any { return "hello, gzip" })
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("Content-Encoding = %q, want %q", got, "gzip")
+	}
+	if got := rec.Header().Get("Vary"); got != "Accept-Encoding" {
+		t.Fatalf("Vary = %q, want %q", got, "Accept-Encoding")
+	}
+
+	gr, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+	defer gr.Close()
+	body, err := io.ReadAll(gr)
+	if err != nil {
+		t.Fatalf("reading gunzipped body: %v", err)
+	}
+	if got, want := string(body), "hello, gzip"; got != want {
+		t.Fatalf("gunzipped body = %q, want %q", got, want)
+	}
+}
+
+// TestGzipSkipsCompressionWithoutAcceptEncoding guards against the
+// middleware compressing a response the client never asked for.
+func TestGzipSkipsCompressionWithoutAcceptEncoding(t *testing.T) {
+	router := crumb.New()
+	router.Use(Gzip())
+	router.GET("/", func(ctx *crumb.HttpContext) any { return "hello, plain" })
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Content-Encoding"); got != "" {
+		t.Fatalf("Content-Encoding = %q, want empty", got)
+	}
+	if got, want := rec.Body.String(), "hello, plain"; got != want {
+		t.Fatalf("body = %q, want %q", got, want)
+	}
+}
+
+func TestRequestIDGeneratesWhenAbsent(t *testing.T) {
+	var seen string
+	handler := RequestID()(func(ctx *crumb.HttpContext) any {
+		seen = RequestIDFrom(ctx)
+		return "ok"
+	})
+
+	router := crumb.New()
+	router.GET("/", handler)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if seen == "" {
+		t.Fatal("expected a generated request ID, got empty string")
+	}
+	if got := rec.Header().Get("X-Request-ID"); got != seen {
+		t.Fatalf("X-Request-ID header = %q, want %q", got, seen)
+	}
+}
+
+func TestRequestIDEchoesIncoming(t *testing.T) {
+	handler := RequestID()(func(ctx *crumb.HttpContext) any {
+		return RequestIDFrom(ctx)
+	})
+
+	router := crumb.New()
+	router.GET("/", handler)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Request-ID", "fixed-id")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("X-Request-ID"); got != "fixed-id" {
+		t.Fatalf("X-Request-ID header = %q, want %q", got, "fixed-id")
+	}
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/tuplescompany/crumbjs"
+)
+
+// Recover returns middleware that catches panics raised by downstream
+// handlers, writes a 500 JSON error envelope and logs the stack trace.
+func Recover() crumb.Middleware {
+	return func(next crumb.HandlerFunc) crumb.HandlerFunc {
+		return func(ctx *crumb.HttpContext) (result any) {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("panic recovered: %v\n%s", r, debug.Stack())
+					result = ctx.JSON(http.StatusInternalServerError, crumb.ErrorResponse{Error: "internal server error"})
+				}
+			}()
+			return next(ctx)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/tuplescompany/crumbjs"
+)
+
+const (
+	requestIDHeader = "X-Request-ID"
+	requestIDKey    = "middleware.requestID"
+)
+
+// RequestID returns middleware that reads X-Request-ID from the request,
+// generating one if absent, stashes it on the HttpContext (retrievable
+// via RequestIDFrom) and echoes it back on the response.
+func RequestID() crumb.Middleware {
+	return func(next crumb.HandlerFunc) crumb.HandlerFunc {
+		return func(ctx *crumb.HttpContext) any {
+			id := ctx.Request().Header.Get(requestIDHeader)
+			if id == "" {
+				id = generateRequestID()
+			}
+			ctx.Set(requestIDKey, id)
+			ctx.SetHeader(requestIDHeader, id)
+			return next(ctx)
+		}
+	}
+}
+
+// RequestIDFrom returns the request ID stashed by RequestID, or "" if the
+// middleware was not installed on this route.
+func RequestIDFrom(ctx *crumb.HttpContext) string {
+	id, _ := ctx.Get(requestIDKey).(string)
+	return id
+}
+
+func generateRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
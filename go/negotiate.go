@@ -0,0 +1,76 @@
+package crumb
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// Representation pairs a media type with the value that satisfies it.
+type Representation struct {
+	ContentType string
+	Value       any
+}
+
+// Negotiated wraps a set of representations of the same resource so the
+// response encoder can pick one based on the request's Accept header.
+// Representations are tried in the order they were passed to Negotiate;
+// the first one is used as the fallback when the client sends no Accept
+// header, sends "*/*", or asks for a type none of the representations
+// satisfy.
+type Negotiated struct {
+	representations []Representation
+}
+
+// Negotiate wraps reps so a handler can return multiple representations
+// of the same resource and let the client's Accept header decide which
+// one is written, e.g.:
+//
+//	return crumb.Negotiate(
+//	    crumb.Representation{ContentType: "application/json", Value: user},
+//	    crumb.Representation{ContentType: "text/html", Value: crumb.HTML(page)},
+//	)
+func Negotiate(reps ...Representation) Negotiated {
+	return Negotiated{representations: reps}
+}
+
+// pick returns the representation that best matches the Accept header,
+// falling back to the first representation.
+func (n Negotiated) pick(accept string) Representation {
+	if accept != "" && accept != "*/*" {
+		for _, want := range strings.Split(accept, ",") {
+			want = strings.TrimSpace(strings.SplitN(want, ";", 2)[0])
+			for _, rep := range n.representations {
+				if want == rep.ContentType {
+					return rep
+				}
+			}
+		}
+	}
+	return n.representations[0]
+}
+
+// negotiate writes the representation of n chosen by the request's Accept
+// header, with the given status code.
+func (c *HttpContext) negotiate(code int, n Negotiated) {
+	if len(n.representations) == 0 {
+		c.res.WriteHeader(code)
+		c.written = true
+		return
+	}
+	rep := n.pick(c.req.Header.Get("Accept"))
+	c.res.Header().Set("Content-Type", rep.ContentType)
+	c.res.WriteHeader(code)
+	c.written = true
+
+	switch v := rep.Value.(type) {
+	case string:
+		_, _ = io.WriteString(c.res, v)
+	case HTMLString:
+		_, _ = io.WriteString(c.res, string(v))
+	case []byte:
+		_, _ = c.res.Write(v)
+	default:
+		_ = json.NewEncoder(c.res).Encode(v)
+	}
+}
@@ -0,0 +1,48 @@
+package crumb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNegotiateHonorsAcceptHeader(t *testing.T) {
+	router := newRouter()
+	router.GET("/resource", func(ctx *HttpContext) any {
+		return Negotiate(
+			Representation{ContentType: "application/json", Value: map[string]string{"hello": "world"}},
+			Representation{ContentType: "text/html", Value: HTML("<p>hello</p>")},
+		)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/resource", nil)
+	req.Header.Set("Accept", "text/html")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if ct := rec.Header().Get("Content-Type"); ct != "text/html" {
+		t.Fatalf("Content-Type = %q, want %q", ct, "text/html")
+	}
+	if !strings.Contains(rec.Body.String(), "<p>hello</p>") {
+		t.Fatalf("body = %q, want it to contain the HTML representation", rec.Body.String())
+	}
+}
+
+func TestNegotiateFallsBackWithoutAcceptHeader(t *testing.T) {
+	router := newRouter()
+	router.GET("/resource", func(ctx *HttpContext) any {
+		return Negotiate(
+			Representation{ContentType: "application/json", Value: map[string]string{"hello": "world"}},
+			Representation{ContentType: "text/html", Value: HTML("<p>hello</p>")},
+		)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/resource", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("Content-Type = %q, want %q", ct, "application/json")
+	}
+}
@@ -0,0 +1,152 @@
+package crumb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// HTMLString marks a handler's returned string as HTML rather than plain
+// text, steering the response encoder to write it with a text/html
+// Content-Type instead of text/plain.
+type HTMLString string
+
+// HTML wraps s so a handler can return HTML content directly instead of
+// calling ctx.HTML.
+func HTML(s string) HTMLString {
+	return HTMLString(s)
+}
+
+// ErrorResponse is the JSON body written when a handler returns (or the
+// response encoder otherwise surfaces) an error.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// StatusCoder lets an error customize the HTTP status code the response
+// encoder uses when a handler returns it, instead of the default 500.
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// FieldErrorer lets an error expose a structured field→message map, which
+// writeError renders as the JSON body directly instead of flattening it
+// through Error() into a single string. ValidationError implements this.
+type FieldErrorer interface {
+	Fields() map[string]string
+}
+
+// Status sets the status code the response encoder uses when it writes the
+// handler's return value (e.g. ctx.Status(201); return user) and returns c.
+// JSON, HTML, String and Stream take their own code and ignore it.
+func (c *HttpContext) Status(code int) *HttpContext {
+	c.status = code
+	return c
+}
+
+// JSON writes v to the response body as JSON with the given status code.
+func (c *HttpContext) JSON(code int, v any) any {
+	c.res.Header().Set("Content-Type", "application/json; charset=utf-8")
+	c.res.WriteHeader(code)
+	c.written = true
+	_ = json.NewEncoder(c.res).Encode(v)
+	return nil
+}
+
+// HTML writes s to the response body as text/html with the given status
+// code.
+func (c *HttpContext) HTML(code int, s string) any {
+	c.res.Header().Set("Content-Type", "text/html; charset=utf-8")
+	c.res.WriteHeader(code)
+	c.written = true
+	_, _ = io.WriteString(c.res, s)
+	return nil
+}
+
+// String writes a formatted string to the response body as text/plain
+// with the given status code.
+func (c *HttpContext) String(code int, format string, args ...any) any {
+	c.res.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	c.res.WriteHeader(code)
+	c.written = true
+	fmt.Fprintf(c.res, format, args...)
+	return nil
+}
+
+// Stream copies r to the response body with the given status code and
+// Content-Type, without buffering it in memory first.
+func (c *HttpContext) Stream(code int, contentType string, r io.Reader) any {
+	c.res.Header().Set("Content-Type", contentType)
+	c.res.WriteHeader(code)
+	c.written = true
+	_, _ = io.Copy(c.res, r)
+	return nil
+}
+
+// Redirect writes an HTTP redirect to url with the given status code.
+func (c *HttpContext) Redirect(code int, url string) any {
+	http.Redirect(c.res, c.req, url, code)
+	c.written = true
+	return nil
+}
+
+// Cookie returns the named cookie from the request, or an error (typically
+// http.ErrNoCookie) if it is not present.
+func (c *HttpContext) Cookie(name string) (*http.Cookie, error) {
+	return c.req.Cookie(name)
+}
+
+// SetCookie adds a Set-Cookie header to the response.
+func (c *HttpContext) SetCookie(cookie *http.Cookie) {
+	http.SetCookie(c.res, cookie)
+}
+
+// encode inspects v and writes it to the response using the status set
+// via Status (or 200 by default). It is a no-op if the handler already
+// wrote the response itself through JSON, String, HTML, Stream or
+// Redirect.
+func (c *HttpContext) encode(v any) {
+	if c.written {
+		return
+	}
+	code := c.status
+	if code == 0 {
+		code = http.StatusOK
+	}
+	switch val := v.(type) {
+	case nil:
+		c.res.WriteHeader(code)
+	case Negotiated:
+		c.negotiate(code, val)
+	case HTMLString:
+		c.HTML(code, string(val))
+	case string:
+		c.String(code, "%s", val)
+	case []byte:
+		c.res.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		c.res.WriteHeader(code)
+		_, _ = c.res.Write(val)
+	case error:
+		c.writeError(val)
+	case io.Reader:
+		c.Stream(code, "application/octet-stream", val)
+	default:
+		c.JSON(code, val)
+	}
+}
+
+func (c *HttpContext) writeError(err error) {
+	code := http.StatusInternalServerError
+	var sc StatusCoder
+	if errors.As(err, &sc) {
+		code = sc.StatusCode()
+	}
+	var fe FieldErrorer
+	if errors.As(err, &fe) {
+		c.JSON(code, fe.Fields())
+		return
+	}
+	c.JSON(code, ErrorResponse{Error: err.Error()})
+}
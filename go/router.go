@@ -0,0 +1,157 @@
+package crumb
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// HandlerFunc is invoked for a matched route. Its return value is handed
+// to the response encoder, which decides how to write it to the client.
+type HandlerFunc func(ctx *HttpContext) any
+
+// Middleware wraps a HandlerFunc to add cross-cutting behaviour such as
+// logging, authentication or panic recovery. Middleware registered on a
+// Router runs in registration order, outermost first.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// route is a single registered endpoint, already wrapped with whatever
+// middleware chain was active on its Router at registration time.
+type route struct {
+	method string
+	path   string
+	fn     HandlerFunc
+}
+
+// routeTable is the set of routes shared by a Router and every sub-router
+// derived from it via WithWrappers, compiled into one matching trie per
+// HTTP method.
+type routeTable struct {
+	trees   map[string]*node
+	statics []*staticMount
+}
+
+func (t *routeTable) treeFor(method string) *node {
+	n, ok := t.trees[method]
+	if !ok {
+		n = newNode()
+		t.trees[method] = n
+	}
+	return n
+}
+
+// allowedMethods returns, sorted, every method (other than OPTIONS) that
+// has a route matching segments, used to answer CORS preflight requests
+// for paths that never registered an explicit OPTIONS handler.
+func (t *routeTable) allowedMethods(segments []string) []string {
+	var methods []string
+	for method, tree := range t.trees {
+		if method == http.MethodOptions {
+			continue
+		}
+		if rt, _ := tree.match(segments); rt != nil {
+			methods = append(methods, method)
+		}
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// Router dispatches incoming requests to registered handlers and lets
+// middleware be composed around them. The zero value is not usable; use
+// New to create one.
+type Router struct {
+	table      *routeTable
+	middleware []Middleware
+}
+
+// newRouter creates a root Router with no middleware registered. It is
+// unexported because New (which returns an App) is the framework's entry
+// point; Router itself is only surfaced as the type WithWrappers and
+// Middleware work with.
+func newRouter() *Router {
+	return &Router{table: &routeTable{trees: map[string]*node{}}}
+}
+
+// Use appends middleware to the router. It only affects routes registered
+// afterwards, so middleware order always matches registration order.
+func (r *Router) Use(mw ...Middleware) {
+	r.middleware = append(r.middleware, mw...)
+}
+
+// WithWrappers returns a sub-router that shares r's route table but runs
+// mw around every handler registered through it, layered on top of the
+// middleware r already carries. Later changes to r.middleware do not
+// retroactively apply to routers returned earlier.
+func (r *Router) WithWrappers(mw ...Middleware) *Router {
+	inherited := make([]Middleware, len(r.middleware), len(r.middleware)+len(mw))
+	copy(inherited, r.middleware)
+	return &Router{
+		table:      r.table,
+		middleware: append(inherited, mw...),
+	}
+}
+
+// wrap runs mw around fn, with fn's return value encoded as the innermost
+// step of the chain so every middleware wrapping it (loggers included)
+// observes the response after it has actually been written.
+func (r *Router) wrap(fn HandlerFunc) HandlerFunc {
+	var wrapped HandlerFunc = func(ctx *HttpContext) any {
+		ctx.encode(fn(ctx))
+		return nil
+	}
+	for i := len(r.middleware) - 1; i >= 0; i-- {
+		wrapped = r.middleware[i](wrapped)
+	}
+	return wrapped
+}
+
+func (r *Router) handle(method, path string, fn HandlerFunc) {
+	rt := &route{method: method, path: path, fn: r.wrap(fn)}
+	r.table.treeFor(method).insert(path, rt)
+}
+
+func (r *Router) GET(path string, fn HandlerFunc)     { r.handle(http.MethodGet, path, fn) }
+func (r *Router) POST(path string, fn HandlerFunc)    { r.handle(http.MethodPost, path, fn) }
+func (r *Router) PUT(path string, fn HandlerFunc)     { r.handle(http.MethodPut, path, fn) }
+func (r *Router) PATCH(path string, fn HandlerFunc)   { r.handle(http.MethodPatch, path, fn) }
+func (r *Router) DELETE(path string, fn HandlerFunc)  { r.handle(http.MethodDelete, path, fn) }
+func (r *Router) OPTIONS(path string, fn HandlerFunc) { r.handle(http.MethodOptions, path, fn) }
+func (r *Router) HEAD(path string, fn HandlerFunc)    { r.handle(http.MethodHead, path, fn) }
+
+// ServeHTTP implements http.Handler, matching the request's method and
+// path against the compiled trie for that method.
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	segments := splitSegments(req.URL.Path)
+
+	if tree, ok := r.table.trees[req.Method]; ok {
+		if rt, params := tree.match(segments); rt != nil {
+			ctx := &HttpContext{res: w, req: req, params: params}
+			rt.fn(ctx)
+			return
+		}
+	}
+
+	// No explicit OPTIONS route was registered for this path: synthesize
+	// one from whatever methods are registered, still running it through
+	// the router's middleware so CORS preflight handling works without
+	// every route needing its own OPTIONS handler.
+	if req.Method == http.MethodOptions {
+		if allowed := r.table.allowedMethods(segments); len(allowed) > 0 {
+			ctx := &HttpContext{res: w, req: req}
+			r.wrap(func(ctx *HttpContext) any {
+				ctx.SetHeader("Allow", strings.Join(allowed, ", "))
+				return ctx.String(http.StatusNoContent, "")
+			})(ctx)
+			return
+		}
+	}
+
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		if mount := r.table.matchStatic(req.URL.Path); mount != nil {
+			mount.handler.ServeHTTP(w, req)
+			return
+		}
+	}
+	http.NotFound(w, req)
+}
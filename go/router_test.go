@@ -0,0 +1,98 @@
+package crumb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouterMethodHelpersDispatchByMethod(t *testing.T) {
+	router := newRouter()
+	router.GET("/widgets", func(ctx *HttpContext) any { return "get" })
+	router.POST("/widgets", func(ctx *HttpContext) any { return "post" })
+
+	for _, method := range []string{http.MethodGet, http.MethodPost} {
+		req := httptest.NewRequest(method, "/widgets", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s /widgets status = %d, want 200", method, rec.Code)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/widgets", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("DELETE /widgets status = %d, want 404", rec.Code)
+	}
+}
+
+func TestUseRunsMiddlewareInRegistrationOrderOutermostFirst(t *testing.T) {
+	var order []string
+
+	mw := func(name string) Middleware {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(ctx *HttpContext) any {
+				order = append(order, name+":before")
+				result := next(ctx)
+				order = append(order, name+":after")
+				return result
+			}
+		}
+	}
+
+	router := newRouter()
+	router.Use(mw("outer"), mw("inner"))
+	router.GET("/", func(ctx *HttpContext) any { return "ok" })
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	want := []string{"outer:before", "inner:before", "inner:after", "outer:after"}
+	if len(order) != len(want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("order = %v, want %v", order, want)
+		}
+	}
+}
+
+func TestWithWrappersInheritsParentMiddlewareWithoutAffectingIt(t *testing.T) {
+	var calls []string
+
+	router := newRouter()
+	router.Use(func(next HandlerFunc) HandlerFunc {
+		return func(ctx *HttpContext) any {
+			calls = append(calls, "parent")
+			return next(ctx)
+		}
+	})
+
+	sub := router.WithWrappers(func(next HandlerFunc) HandlerFunc {
+		return func(ctx *HttpContext) any {
+			calls = append(calls, "sub")
+			return next(ctx)
+		}
+	})
+	sub.GET("/sub", func(ctx *HttpContext) any { return "ok" })
+	router.GET("/parent", func(ctx *HttpContext) any { return "ok" })
+
+	req := httptest.NewRequest(http.MethodGet, "/sub", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if got, want := calls, []string{"parent", "sub"}; len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("calls = %v, want %v", got, want)
+	}
+
+	calls = nil
+	req = httptest.NewRequest(http.MethodGet, "/parent", nil)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if len(calls) != 1 || calls[0] != "parent" {
+		t.Fatalf("calls = %v, want [parent] (sub middleware must not leak back onto the parent router)", calls)
+	}
+}
@@ -0,0 +1,183 @@
+package crumb
+
+import (
+	"io/fs"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// StaticOption configures a Static or StaticFS mount.
+type StaticOption func(*staticConfig)
+
+type staticConfig struct {
+	browse       bool
+	hideDotfiles bool
+	cacheControl string
+	notFound     HandlerFunc
+}
+
+// WithDirectoryListing enables directory listing for a mount. Listing is
+// disabled by default.
+func WithDirectoryListing() StaticOption {
+	return func(c *staticConfig) { c.browse = true }
+}
+
+// WithHideDotfiles denies access to any path whose segments include one
+// starting with "." (".env", ".git", ...).
+func WithHideDotfiles() StaticOption {
+	return func(c *staticConfig) { c.hideDotfiles = true }
+}
+
+// WithCacheControl sets the Cache-Control header written for every file
+// served from the mount.
+func WithCacheControl(value string) StaticOption {
+	return func(c *staticConfig) { c.cacheControl = value }
+}
+
+// WithStaticNotFound sets the handler invoked, instead of a plain 404,
+// when a requested file does not exist under the mount.
+func WithStaticNotFound(fn HandlerFunc) StaticOption {
+	return func(c *staticConfig) { c.notFound = fn }
+}
+
+// staticMount is a URL prefix backed by a filesystem, checked after the
+// route trie fails to match.
+type staticMount struct {
+	prefix  string
+	handler http.Handler
+}
+
+// Static mounts the contents of the local directory dir under urlPrefix.
+func (r *Router) Static(urlPrefix, dir string, opts ...StaticOption) {
+	r.StaticFS(urlPrefix, os.DirFS(dir), opts...)
+}
+
+// StaticFS mounts fsys under urlPrefix, stripping the prefix before
+// resolving files the same way http.StripPrefix + http.FileServer would.
+// It accepts an embed.FS so binaries can ship their assets.
+func (r *Router) StaticFS(urlPrefix string, fsys fs.FS, opts ...StaticOption) {
+	cfg := &staticConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	wrapped := &filteredFS{FS: fsys, hideDotfiles: cfg.hideDotfiles, browse: cfg.browse}
+	fileServer := http.FileServer(http.FS(wrapped))
+	prefix := strings.TrimSuffix(urlPrefix, "/")
+	stripped := http.StripPrefix(prefix, fileServer)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if cfg.cacheControl != "" {
+			w.Header().Set("Cache-Control", cfg.cacheControl)
+		}
+		if cfg.notFound == nil {
+			stripped.ServeHTTP(w, req)
+			return
+		}
+		before := w.Header().Clone()
+		intercept := &notFoundInterceptor{ResponseWriter: w}
+		stripped.ServeHTTP(intercept, req)
+		if intercept.triggered {
+			// http.FileServer's internal http.Error call set headers like
+			// Content-Type and X-Content-Type-Options on w before its
+			// WriteHeader(404) was intercepted; drop anything it added so
+			// none of it leaks into the custom not-found response.
+			resetHeader(w.Header(), before)
+			ctx := &HttpContext{res: w, req: req}
+			ctx.encode(cfg.notFound(ctx))
+		}
+	})
+
+	r.table.statics = append(r.table.statics, &staticMount{prefix: prefix, handler: handler})
+}
+
+// filteredFS wraps an fs.FS to hide dotfiles and, unless browse is set,
+// to refuse to serve a directory listing for directories without an
+// index.html.
+type filteredFS struct {
+	fs.FS
+	hideDotfiles bool
+	browse       bool
+}
+
+func (f *filteredFS) Open(name string) (fs.File, error) {
+	if f.hideDotfiles && containsDotfile(name) {
+		return nil, fs.ErrNotExist
+	}
+	file, err := f.FS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	if f.browse {
+		return file, nil
+	}
+	info, err := file.Stat()
+	if err != nil || !info.IsDir() {
+		return file, nil
+	}
+	index, err := f.FS.Open(name + "/index.html")
+	if err != nil {
+		file.Close()
+		return nil, fs.ErrNotExist
+	}
+	index.Close()
+	return file, nil
+}
+
+func containsDotfile(name string) bool {
+	for _, part := range strings.Split(name, "/") {
+		if strings.HasPrefix(part, ".") && part != "." {
+			return true
+		}
+	}
+	return false
+}
+
+// resetHeader replaces h's contents with snapshot, discarding anything
+// added to h after the snapshot was taken.
+func resetHeader(h, snapshot http.Header) {
+	for k := range h {
+		delete(h, k)
+	}
+	for k, v := range snapshot {
+		h[k] = v
+	}
+}
+
+// notFoundInterceptor swallows http.FileServer's default 404 response so
+// a custom not-found handler can run in its place.
+type notFoundInterceptor struct {
+	http.ResponseWriter
+	triggered bool
+}
+
+func (w *notFoundInterceptor) WriteHeader(code int) {
+	if code == http.StatusNotFound {
+		w.triggered = true
+		return
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *notFoundInterceptor) Write(b []byte) (int, error) {
+	if w.triggered {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// matchStatic returns the longest registered static mount whose prefix
+// reqPath equals or lies under, or nil if none matches.
+func (t *routeTable) matchStatic(reqPath string) *staticMount {
+	var best *staticMount
+	for _, m := range t.statics {
+		if reqPath != m.prefix && !strings.HasPrefix(reqPath, m.prefix+"/") {
+			continue
+		}
+		if best == nil || len(m.prefix) > len(best.prefix) {
+			best = m
+		}
+	}
+	return best
+}
@@ -0,0 +1,132 @@
+package crumb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"public.txt":      {Data: []byte("public")},
+		".env":            {Data: []byte("secret")},
+		"docs/index.html": {Data: []byte("index")},
+		"docs/page.txt":   {Data: []byte("page")},
+	}
+}
+
+func TestStaticHidesDotfilesWhenConfigured(t *testing.T) {
+	router := newRouter()
+	router.StaticFS("/files", testFS(), WithHideDotfiles())
+
+	req := httptest.NewRequest(http.MethodGet, "/files/.env", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", rec.Code)
+	}
+}
+
+func TestStaticAllowsDotfilesByDefault(t *testing.T) {
+	router := newRouter()
+	router.StaticFS("/files", testFS())
+
+	req := httptest.NewRequest(http.MethodGet, "/files/.env", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+}
+
+func TestStaticDeniesDirectoryListingByDefault(t *testing.T) {
+	router := newRouter()
+	router.StaticFS("/files", testFS())
+
+	req := httptest.NewRequest(http.MethodGet, "/files/docs/", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK || rec.Body.String() != "index" {
+		t.Fatalf("status = %d, body = %q, want 200 with index.html content", rec.Code, rec.Body.String())
+	}
+
+	// A directory without an index.html must not be listed.
+	router = newRouter()
+	router.StaticFS("/files", fstest.MapFS{"assets/file.txt": {Data: []byte("x")}})
+
+	req = httptest.NewRequest(http.MethodGet, "/files/assets/", nil)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404 (directory listing disabled)", rec.Code)
+	}
+}
+
+func TestStaticAllowsDirectoryListingWhenEnabled(t *testing.T) {
+	fsys := fstest.MapFS{"assets/file.txt": {Data: []byte("x")}}
+	router := newRouter()
+	router.StaticFS("/files", fsys, WithDirectoryListing())
+
+	req := httptest.NewRequest(http.MethodGet, "/files/assets/", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (directory listing enabled)", rec.Code)
+	}
+}
+
+func TestStaticSetsCacheControlHeader(t *testing.T) {
+	router := newRouter()
+	router.StaticFS("/files", testFS(), WithCacheControl("public, max-age=3600"))
+
+	req := httptest.NewRequest(http.MethodGet, "/files/public.txt", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Cache-Control"); got != "public, max-age=3600" {
+		t.Fatalf("Cache-Control = %q, want %q", got, "public, max-age=3600")
+	}
+}
+
+func TestStaticWithNotFoundRunsCustomHandler(t *testing.T) {
+	router := newRouter()
+	router.StaticFS("/files", testFS(), WithStaticNotFound(func(ctx *HttpContext) any {
+		return ctx.String(http.StatusTeapot, "nope")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/files/missing.txt", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusTeapot || rec.Body.String() != "nope" {
+		t.Fatalf("status = %d, body = %q, want 418 with custom body", rec.Code, rec.Body.String())
+	}
+}
+
+// TestStaticWithNotFoundDoesNotLeakFileServerHeaders guards against
+// http.FileServer's internal http.Error call (Content-Type,
+// X-Content-Type-Options) bleeding through into the custom not-found
+// response it was supposed to replace entirely.
+func TestStaticWithNotFoundDoesNotLeakFileServerHeaders(t *testing.T) {
+	router := newRouter()
+	router.StaticFS("/files", testFS(), WithStaticNotFound(func(ctx *HttpContext) any {
+		return ctx.JSON(http.StatusNotFound, map[string]string{"error": "not found"})
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/files/missing.txt", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("X-Content-Type-Options"); got != "" {
+		t.Fatalf("X-Content-Type-Options = %q, want empty (leaked from http.FileServer's 404)", got)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/json; charset=utf-8" {
+		t.Fatalf("Content-Type = %q, want %q", got, "application/json; charset=utf-8")
+	}
+}
@@ -0,0 +1,120 @@
+package crumb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// param is a single path parameter captured while matching a request
+// against the trie.
+type param struct {
+	key   string
+	value string
+}
+
+// node is one segment of a registered path. Each method gets its own
+// trie root so matching never has to branch on the HTTP method itself.
+type node struct {
+	staticChildren map[string]*node
+	paramChild     *node
+	paramName      string
+	wildcardChild  *node
+	wildcardName   string
+	route          *route
+}
+
+func newNode() *node {
+	return &node{staticChildren: map[string]*node{}}
+}
+
+// splitSegments turns "/users/:id/" into []string{"users", ":id"},
+// treating the root path as zero segments.
+func splitSegments(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// insert registers rt at path, compiling the pattern into trie nodes. It
+// panics on ambiguous or duplicate registrations so conflicts surface at
+// startup rather than as a routing surprise at request time.
+func (n *node) insert(path string, rt *route) {
+	cur := n
+	segments := splitSegments(path)
+	for i, seg := range segments {
+		last := i == len(segments)-1
+		switch {
+		case strings.HasPrefix(seg, ":"):
+			name := seg[1:]
+			if cur.paramChild == nil {
+				cur.paramChild = newNode()
+				cur.paramChild.paramName = name
+			} else if cur.paramChild.paramName != name {
+				panic(fmt.Sprintf("crumb: route %q conflicts with an existing param %q at the same position (got %q)", path, cur.paramChild.paramName, name))
+			}
+			cur = cur.paramChild
+		case strings.HasPrefix(seg, "*"):
+			if !last {
+				panic(fmt.Sprintf("crumb: wildcard segment %q must be the last segment in %q", seg, path))
+			}
+			if cur.wildcardChild != nil {
+				panic(fmt.Sprintf("crumb: duplicate wildcard route %q", path))
+			}
+			if len(cur.staticChildren) > 0 {
+				panic(fmt.Sprintf("crumb: wildcard route %q is ambiguous with an existing static route at the same position", path))
+			}
+			cur.wildcardChild = newNode()
+			cur.wildcardChild.wildcardName = seg[1:]
+			cur = cur.wildcardChild
+		default:
+			if cur.wildcardChild != nil {
+				panic(fmt.Sprintf("crumb: static route %q is ambiguous with an existing wildcard route at the same position", path))
+			}
+			child, ok := cur.staticChildren[seg]
+			if !ok {
+				child = newNode()
+				cur.staticChildren[seg] = child
+			}
+			cur = child
+		}
+	}
+	if cur.route != nil {
+		panic(fmt.Sprintf("crumb: duplicate route registered for %q", path))
+	}
+	cur.route = rt
+}
+
+// match walks segments through the trie, preferring static children over
+// params and params over a trailing wildcard at each position. A
+// preference that fails deeper in the tree is backtracked: e.g. a static
+// child that matches the current segment but leads to a dead end no
+// longer shadows a param or wildcard sibling that would have matched the
+// rest of the path.
+func (n *node) match(segments []string) (*route, []param) {
+	if len(segments) == 0 {
+		return n.route, nil
+	}
+
+	seg, rest := segments[0], segments[1:]
+
+	if child, ok := n.staticChildren[seg]; ok {
+		if rt, params := child.match(rest); rt != nil {
+			return rt, params
+		}
+	}
+
+	if n.paramChild != nil {
+		if rt, params := n.paramChild.match(rest); rt != nil {
+			return rt, append([]param{{key: n.paramChild.paramName, value: seg}}, params...)
+		}
+	}
+
+	if n.wildcardChild != nil && n.wildcardChild.route != nil {
+		params := []param{{key: n.wildcardChild.wildcardName, value: strings.Join(segments, "/")}}
+		return n.wildcardChild.route, params
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,123 @@
+package crumb
+
+import "testing"
+
+func TestTrieMatchesStaticParamAndWildcard(t *testing.T) {
+	root := newNode()
+	root.insert("/users/:id", &route{path: "/users/:id"})
+	root.insert("/files/*path", &route{path: "/files/*path"})
+
+	rt, params := root.match(splitSegments("/users/42"))
+	if rt == nil || rt.path != "/users/:id" {
+		t.Fatalf("match(/users/42) = %v, want /users/:id route", rt)
+	}
+	if len(params) != 1 || params[0].key != "id" || params[0].value != "42" {
+		t.Fatalf("params = %v, want [{id 42}]", params)
+	}
+
+	rt, params = root.match(splitSegments("/files/a/b/c.txt"))
+	if rt == nil || rt.path != "/files/*path" {
+		t.Fatalf("match(/files/a/b/c.txt) = %v, want /files/*path route", rt)
+	}
+	if len(params) != 1 || params[0].key != "path" || params[0].value != "a/b/c.txt" {
+		t.Fatalf("params = %v, want [{path a/b/c.txt}]", params)
+	}
+}
+
+func TestTrieInsertPanicsOnDuplicateRoute(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected insert to panic on a duplicate route")
+		}
+	}()
+	root := newNode()
+	root.insert("/users/:id", &route{path: "/users/:id"})
+	root.insert("/users/:id", &route{path: "/users/:id"})
+}
+
+func TestTrieInsertPanicsOnConflictingParamNames(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected insert to panic on conflicting param names")
+		}
+	}()
+	root := newNode()
+	root.insert("/users/:id", &route{path: "/users/:id"})
+	root.insert("/users/:userID/profile", &route{path: "/users/:userID/profile"})
+}
+
+// TestTrieInsertPanicsOnStaticUnderWildcard guards against a static route
+// silently shadowing part of a wildcard's range, which is the ambiguity
+// the framework is supposed to reject at registration time.
+func TestTrieInsertPanicsOnStaticUnderWildcard(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected insert to panic when a static route conflicts with an existing wildcard")
+		}
+	}()
+	root := newNode()
+	root.insert("/files/*path", &route{path: "/files/*path"})
+	root.insert("/files/readme", &route{path: "/files/readme"})
+}
+
+// TestTrieInsertPanicsOnWildcardUnderStatic is the mirror image: a
+// wildcard registered after a static sibling is just as ambiguous.
+func TestTrieInsertPanicsOnWildcardUnderStatic(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected insert to panic when a wildcard route conflicts with an existing static route")
+		}
+	}()
+	root := newNode()
+	root.insert("/files/readme", &route{path: "/files/readme"})
+	root.insert("/files/*path", &route{path: "/files/*path"})
+}
+
+// TestTrieMatchBacktracksFromDeadEndStatic guards against a static route
+// that shares a prefix with a deeper param route shadowing it: failing to
+// match past the static branch must fall back to the param branch rather
+// than 404ing outright.
+func TestTrieMatchBacktracksFromDeadEndStatic(t *testing.T) {
+	root := newNode()
+	root.insert("/users/list", &route{path: "/users/list"})
+	root.insert("/users/:id/profile", &route{path: "/users/:id/profile"})
+
+	rt, params := root.match(splitSegments("/users/list/profile"))
+	if rt == nil || rt.path != "/users/:id/profile" {
+		t.Fatalf("match(/users/list/profile) = %v, want /users/:id/profile route", rt)
+	}
+	if len(params) != 1 || params[0].key != "id" || params[0].value != "list" {
+		t.Fatalf("params = %v, want [{id list}]", params)
+	}
+
+	rt, _ = root.match(splitSegments("/users/list"))
+	if rt == nil || rt.path != "/users/list" {
+		t.Fatalf("match(/users/list) = %v, want /users/list route", rt)
+	}
+}
+
+// TestTrieMatchReachesWildcardBehindParam guards against a trailing
+// wildcard becoming permanently unreachable when a param sibling consumes
+// its first segment: a param route only ever matches one more segment, so
+// any request with extra segments must fall back to the wildcard.
+func TestTrieMatchReachesWildcardBehindParam(t *testing.T) {
+	root := newNode()
+	root.insert("/users/:id", &route{path: "/users/:id"})
+	root.insert("/users/*rest", &route{path: "/users/*rest"})
+
+	rt, params := root.match(splitSegments("/users/42"))
+	if rt == nil || rt.path != "/users/:id" {
+		t.Fatalf("match(/users/42) = %v, want /users/:id route", rt)
+	}
+	if len(params) != 1 || params[0].key != "id" || params[0].value != "42" {
+		t.Fatalf("params = %v, want [{id 42}]", params)
+	}
+
+	rt, params = root.match(splitSegments("/users/42/43"))
+	if rt == nil || rt.path != "/users/*rest" {
+		t.Fatalf("match(/users/42/43) = %v, want /users/*rest route", rt)
+	}
+	if len(params) != 1 || params[0].key != "rest" || params[0].value != "42/43" {
+		t.Fatalf("params = %v, want [{rest 42/43}]", params)
+	}
+}
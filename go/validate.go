@@ -0,0 +1,80 @@
+package crumb
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Validator validates a bound value, returning a structured error the
+// response encoder can render as a 422 JSON body. SetValidator installs
+// one package-wide; the default wraps go-playground/validator using
+// struct `validate` tags.
+type Validator interface {
+	Validate(v any) error
+}
+
+var defaultValidator Validator = NewDefaultValidator()
+
+// SetValidator replaces the package-wide Validator used by
+// HttpContext.BindAndValidate.
+func SetValidator(v Validator) {
+	defaultValidator = v
+}
+
+// ValidationError maps a struct field name to a human-readable message.
+// It satisfies StatusCoder so the response encoder writes it as 422, and
+// FieldErrorer so the encoder writes the field→message map itself as the
+// JSON body instead of flattening it through Error().
+type ValidationError map[string]string
+
+func (e ValidationError) Error() string {
+	msgs := make([]string, 0, len(e))
+	for field, msg := range e {
+		msgs = append(msgs, field+": "+msg)
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// StatusCode makes ValidationError satisfy StatusCoder.
+func (e ValidationError) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+// Fields makes ValidationError satisfy FieldErrorer.
+func (e ValidationError) Fields() map[string]string {
+	return e
+}
+
+// DefaultValidator is the Validator used unless SetValidator installs a
+// different one. It validates structs via go-playground/validator,
+// driven by `validate` struct tags.
+type DefaultValidator struct {
+	validate *validator.Validate
+}
+
+// NewDefaultValidator creates a DefaultValidator with a fresh
+// validator.Validate instance.
+func NewDefaultValidator() *DefaultValidator {
+	return &DefaultValidator{validate: validator.New()}
+}
+
+// Validate runs go-playground/validator's struct validation on v,
+// translating any *validator.ValidationErrors into a ValidationError.
+func (d *DefaultValidator) Validate(v any) error {
+	err := d.validate.Struct(v)
+	if err == nil {
+		return nil
+	}
+	fieldErrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return err
+	}
+	fields := make(ValidationError, len(fieldErrs))
+	for _, fe := range fieldErrs {
+		fields[fe.Field()] = fmt.Sprintf("failed on the %q tag", fe.Tag())
+	}
+	return fields
+}